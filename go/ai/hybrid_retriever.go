@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ai
+
+import (
+	"context"
+	"sync"
+)
+
+// HybridRetrieverOptions configures NewHybridRetriever.
+type HybridRetrieverOptions struct {
+	// Retrievers are queried concurrently with the same RetrieverRequest;
+	// their results are concatenated, in order, before reranking.
+	Retrievers []Retriever
+	// Reranker combines and reorders the concatenated results. Use
+	// NewRRFReranker to fuse the retrievers' rankings, or NewMMRReranker to
+	// diversify the merged pool. If nil, results are simply concatenated.
+	Reranker Reranker
+	// K is the number of documents HybridRetriever returns. Zero means all
+	// of the reranker's output (or, with no Reranker, all concatenated
+	// results).
+	K int
+}
+
+// hybridRetriever is a Retriever that fans a request out to several
+// sub-retrievers and fuses their results through a Reranker.
+type hybridRetriever struct {
+	opts HybridRetrieverOptions
+}
+
+// NewHybridRetriever returns a Retriever that runs opts.Retrievers
+// concurrently and pipes their combined results through opts.Reranker. It
+// lets callers replace a single-retriever pattern like:
+//
+//	docs, err := retriever.Retrieve(ctx, req)
+//
+// with a multi-retriever one:
+//
+//	hybrid := ai.NewHybridRetriever(ai.HybridRetrieverOptions{
+//		Retrievers: []ai.Retriever{vectorRetriever, bm25Retriever},
+//		Reranker:   ai.NewRRFReranker(),
+//	})
+//	docs, err := hybrid.Retrieve(ctx, req)
+func NewHybridRetriever(opts HybridRetrieverOptions) Retriever {
+	return &hybridRetriever{opts: opts}
+}
+
+func (h *hybridRetriever) Retrieve(ctx context.Context, req *RetrieverRequest) (*RetrieverResponse, error) {
+	lists := make([][]*Document, len(h.opts.Retrievers))
+	errs := make([]error, len(h.opts.Retrievers))
+
+	var wg sync.WaitGroup
+	for i, r := range h.opts.Retrievers {
+		wg.Add(1)
+		go func(i int, r Retriever) {
+			defer wg.Done()
+			resp, err := r.Retrieve(ctx, req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			lists[i] = resp.Documents
+		}(i, r)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var all []*Document
+	sizes := make([]int, len(lists))
+	for i, l := range lists {
+		all = append(all, l...)
+		sizes[i] = len(l)
+	}
+
+	docs := all
+	if h.opts.Reranker != nil {
+		resp, err := h.opts.Reranker.Rerank(ctx, &RerankerRequest{
+			Query:     req.Query,
+			Documents: all,
+			Options:   mergeRerankOptions(req.Options, sizes),
+		})
+		if err != nil {
+			return nil, err
+		}
+		docs = resp.Documents
+	}
+
+	if h.opts.K > 0 && h.opts.K < len(docs) {
+		docs = docs[:h.opts.K]
+	}
+	return &RetrieverResponse{Documents: docs}, nil
+}
+
+// mergeRerankOptions builds the Options passed to the Reranker: when the
+// request's Options are nil or RRFOptions, it keeps any caller-supplied
+// RRFOptions.K and fills in ListSizes from the sub-retrievers actually run;
+// MMROptions (or anything else) pass through unchanged, without ListSizes.
+func mergeRerankOptions(reqOptions any, sizes []int) any {
+	switch o := reqOptions.(type) {
+	case RRFOptions:
+		o.ListSizes = sizes
+		return o
+	case nil:
+		return RRFOptions{ListSizes: sizes}
+	default:
+		return o
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// A Reranker reorders a list of candidate Documents by relevance to a query.
+// It is the counterpart to Retriever: a Retriever fetches candidates, and a
+// Reranker refines their order (or fuses the orders of several Retrievers).
+type Reranker interface {
+	// Rerank reorders req.Documents and returns the result. It does not
+	// fetch new documents.
+	Rerank(ctx context.Context, req *RerankerRequest) (*RerankerResponse, error)
+}
+
+// RerankerRequest is the input to a Reranker.
+type RerankerRequest struct {
+	// Query is the query the candidates are being reranked against.
+	Query *Document
+	// Documents are the candidates to reorder.
+	Documents []*Document
+	// Options holds reranker-specific configuration, such as RRFOptions or
+	// MMROptions.
+	Options any
+}
+
+// RerankerResponse is the output of a Reranker.
+type RerankerResponse struct {
+	// Documents is Documents from the request, reordered (and possibly
+	// truncated or deduplicated) by the reranker.
+	Documents []*Document
+}
+
+// RerankerOptions are the options for defining a Reranker.
+type RerankerOptions struct {
+	// ConfigSchema describes the shape of the per-call options a caller may
+	// pass as RerankerRequest.Options.
+	ConfigSchema any
+	// Info describes the reranker for the dev UI and model catalogs.
+	Info *RerankerInfo
+}
+
+// RerankerInfo describes a Reranker.
+type RerankerInfo struct {
+	// Label is a human-readable name for the reranker.
+	Label string
+}
+
+// RerankFunc implements the reranking logic for a Reranker defined with
+// NewReranker.
+type RerankFunc func(ctx context.Context, req *RerankerRequest) (*RerankerResponse, error)
+
+// reranker is a Reranker built from a RerankFunc, analogous to how
+// Retrievers are built around a retrieve function.
+type reranker struct {
+	provider string
+	name     string
+	info     *RerankerInfo
+	fn       RerankFunc
+}
+
+// NewReranker creates a Reranker from a provider name, a reranker name, and
+// the function that implements the reranking. It does not register
+// anything in a Registry; genkit.DefineReranker does that and returns the
+// Reranker NewReranker builds around the registered action, and
+// NewRRFReranker/NewMMRReranker use it directly to build unregistered,
+// ad hoc rerankers.
+func NewReranker(provider, name string, opts *RerankerOptions, fn RerankFunc) Reranker {
+	r := &reranker{provider: provider, name: name, fn: fn}
+	if opts != nil {
+		r.info = opts.Info
+	}
+	return r
+}
+
+func (r *reranker) Rerank(ctx context.Context, req *RerankerRequest) (*RerankerResponse, error) {
+	return r.fn(ctx, req)
+}
+
+// documentKey returns a string that identifies a Document by its content
+// rather than its pointer, so that the same document retrieved by two
+// different Retrievers can be recognized as a duplicate.
+func documentKey(d *Document) string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		// Documents are always JSON-serializable in practice; fall back to
+		// pointer identity rather than failing the rerank.
+		return fmt.Sprintf("%p", d)
+	}
+	return string(b)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// defaultMMRLambda is the default relevance/diversity trade-off for
+// NewMMRReranker.
+const defaultMMRLambda = 0.5
+
+// MMROptions configures the Reranker returned by NewMMRReranker.
+type MMROptions struct {
+	// Lambda trades off relevance to the query (1) against diversity from
+	// documents already selected (0). Defaults to 0.5.
+	Lambda float64
+	// K is the number of documents to select. Defaults to len(Documents).
+	K int
+}
+
+// NewMMRReranker returns a Reranker that greedily selects documents using
+// Maximal Marginal Relevance: at each step it picks the candidate d that
+// maximizes
+//
+//	Lambda*sim(d, query) - (1-Lambda)*max(sim(d, d') for d' already selected)
+//
+// embedder computes the vectors used for cosine similarity; it is typically
+// the same embedder used to build the candidate pool's index.
+func NewMMRReranker(embedder Embedder) Reranker {
+	return NewReranker("local", "mmr", &RerankerOptions{
+		Info: &RerankerInfo{Label: "Maximal Marginal Relevance"},
+	}, func(ctx context.Context, req *RerankerRequest) (*RerankerResponse, error) {
+		return mmrRerank(ctx, embedder, req)
+	})
+}
+
+func mmrRerank(ctx context.Context, embedder Embedder, req *RerankerRequest) (*RerankerResponse, error) {
+	opts, _ := req.Options.(MMROptions)
+	lambda := opts.Lambda
+	if lambda == 0 {
+		lambda = defaultMMRLambda
+	}
+	k := opts.K
+	if k <= 0 || k > len(req.Documents) {
+		k = len(req.Documents)
+	}
+	if len(req.Documents) == 0 {
+		return &RerankerResponse{}, nil
+	}
+
+	toEmbed := make([]*Document, 0, len(req.Documents)+1)
+	toEmbed = append(toEmbed, req.Query)
+	toEmbed = append(toEmbed, req.Documents...)
+	resp, err := embedder.Embed(ctx, &EmbedRequest{Input: toEmbed})
+	if err != nil {
+		return nil, fmt.Errorf("mmr rerank: embedding candidates: %w", err)
+	}
+	if len(resp.Embeddings) != len(toEmbed) {
+		return nil, fmt.Errorf("mmr rerank: embedder returned %d embeddings for %d inputs", len(resp.Embeddings), len(toEmbed))
+	}
+	queryVec := resp.Embeddings[0].Embedding
+	docVecs := make([][]float32, len(req.Documents))
+	for i, e := range resp.Embeddings[1:] {
+		docVecs[i] = e.Embedding
+	}
+
+	relevance := make([]float64, len(req.Documents))
+	for i, v := range docVecs {
+		relevance[i] = cosineSimilarity(v, queryVec)
+	}
+
+	selected := make([]int, 0, k)
+	chosen := make(map[int]bool, k)
+	for len(selected) < k {
+		best := -1
+		var bestScore float64
+		for i := range req.Documents {
+			if chosen[i] {
+				continue
+			}
+			maxSim := 0.0
+			for n, j := range selected {
+				if sim := cosineSimilarity(docVecs[i], docVecs[j]); n == 0 || sim > maxSim {
+					maxSim = sim
+				}
+			}
+			score := lambda*relevance[i] - (1-lambda)*maxSim
+			if best == -1 || score > bestScore {
+				best = i
+				bestScore = score
+			}
+		}
+		selected = append(selected, best)
+		chosen[best] = true
+	}
+
+	docs := make([]*Document, len(selected))
+	for i, idx := range selected {
+		docs[i] = req.Documents[idx]
+	}
+	return &RerankerResponse{Documents: docs}, nil
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ai
+
+import (
+	"context"
+	"sort"
+)
+
+// defaultRRFK is the default dampening constant for reciprocal rank fusion.
+const defaultRRFK = 60
+
+// RRFOptions configures the Reranker returned by NewRRFReranker.
+type RRFOptions struct {
+	// K dampens the contribution of lower ranks in the fusion score. Higher
+	// values flatten the difference between ranks. Defaults to 60.
+	K int
+	// ListSizes gives the length of each ranked list as they are
+	// concatenated, in order, into RerankerRequest.Documents. Callers that
+	// invoke the reranker directly (rather than through HybridRetriever)
+	// must set this; HybridRetriever fills it in automatically when the
+	// RetrieverRequest's Options are nil or RRFOptions. If it is unset, all
+	// of Documents is treated as one ranked list.
+	ListSizes []int
+}
+
+// NewRRFReranker returns a Reranker that fuses several ranked lists of
+// documents with Reciprocal Rank Fusion: a document's score is the sum, over
+// every list it appears in, of 1/(k+rank), and the fused order is by
+// descending score. Pass the ranked lists concatenated in
+// RerankerRequest.Documents, with their lengths given by
+// RRFOptions.ListSizes. Documents that appear in more than one list are
+// deduplicated by content, keeping the first occurrence.
+//
+// NewRRFReranker is most often used as the Reranker passed to
+// HybridRetriever, which fills in RRFOptions.ListSizes automatically when
+// the request's Options are nil or RRFOptions.
+func NewRRFReranker() Reranker {
+	return NewReranker("local", "rrf", &RerankerOptions{
+		Info: &RerankerInfo{Label: "Reciprocal Rank Fusion"},
+	}, rrfRerank)
+}
+
+func rrfRerank(ctx context.Context, req *RerankerRequest) (*RerankerResponse, error) {
+	opts, _ := req.Options.(RRFOptions)
+	k := opts.K
+	if k == 0 {
+		k = defaultRRFK
+	}
+	listSizes := opts.ListSizes
+	if len(listSizes) == 0 {
+		listSizes = []int{len(req.Documents)}
+	}
+
+	scores := map[string]float64{}
+	docs := map[string]*Document{}
+	var order []string
+
+	pos := 0
+	for _, size := range listSizes {
+		for rank := 0; rank < size && pos < len(req.Documents); rank, pos = rank+1, pos+1 {
+			d := req.Documents[pos]
+			key := documentKey(d)
+			if _, ok := docs[key]; !ok {
+				docs[key] = d
+				order = append(order, key)
+			}
+			scores[key] += 1 / float64(k+rank+1)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	result := make([]*Document, len(order))
+	for i, key := range order {
+		result[i] = docs[key]
+	}
+	return &RerankerResponse{Documents: result}, nil
+}
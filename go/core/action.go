@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+
+	"github.com/firebase/genkit/go/internal/registry"
+)
+
+// ActionType classifies what kind of genkit primitive an Action wraps, so
+// tooling like the dev UI can group and label actions without inspecting
+// their concrete request/response types.
+type ActionType string
+
+// ActionTypeReranker identifies an ai.Reranker registered as an Action.
+const ActionTypeReranker ActionType = "reranker"
+
+// Action is the common representation genkit primitives (retrievers,
+// rerankers, flows, tools, ...) register under in a Registry, so
+// Registry.LookupAction and the dev UI can discover and invoke them
+// uniformly regardless of their concrete In/Out types.
+type Action[In, Out any] struct {
+	// Name is the action's registry key, provider+"/"+name.
+	Name string
+	// Type classifies the action for the dev UI.
+	Type ActionType
+	// Metadata carries action-specific configuration, such as a config
+	// schema, for the dev UI to introspect.
+	Metadata map[string]any
+
+	fn func(context.Context, In) (Out, error)
+}
+
+// DefineAction creates an Action wrapping fn and registers it in r under
+// provider+"/"+name. It returns registry.ErrAlreadyRegistered if that key
+// is already registered to an action that isn't equal to this one.
+func DefineAction[In, Out any](r *registry.Registry, provider, name string, atype ActionType, metadata map[string]any, fn func(context.Context, In) (Out, error)) (*Action[In, Out], error) {
+	a := &Action[In, Out]{
+		Name:     provider + "/" + name,
+		Type:     atype,
+		Metadata: metadata,
+		fn:       fn,
+	}
+	if err := r.RegisterAction(a.Name, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Run invokes the action's underlying function.
+func (a *Action[In, Out]) Run(ctx context.Context, input In) (Out, error) {
+	return a.fn(ctx, input)
+}
@@ -1,39 +1,193 @@
 // Copyright 2024 Google LLC
 // SPDX-License-Identifier: Apache-2.0
 
-
 package core
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/firebase/genkit/go/internal/base"
 )
 
-// A FileFlowStateStore is a FlowStateStore that writes flowStates to files.
+// A FileFlowStateStore is a FlowStateStore that writes flowStates to files,
+// one per id, in a directory.
 type FileFlowStateStore struct {
-	dir string
+	dir    string
+	policy EvictionPolicy
+
+	mu      sync.Mutex // guards idLocks
+	idLocks map[string]*sync.Mutex
 }
 
 // NewFileFlowStateStore creates a FileFlowStateStore that writes traces to the given
 // directory. The directory is created if it does not exist.
-func NewFileFlowStateStore(dir string) (*FileFlowStateStore, error) {
+func NewFileFlowStateStore(dir string, policy EvictionPolicy) (*FileFlowStateStore, error) {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, err
 	}
-	return &FileFlowStateStore{dir: dir}, nil
+	return &FileFlowStateStore{dir: dir, policy: policy, idLocks: map[string]*sync.Mutex{}}, nil
 }
 
+// lockFor returns the mutex serializing writes to id, creating it if
+// necessary, so that concurrent Saves for the same flow run don't race on
+// the same file while Saves for different ids proceed in parallel.
+func (s *FileFlowStateStore) lockFor(id string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.idLocks[id]
+	if !ok {
+		l = &sync.Mutex{}
+		s.idLocks[id] = l
+	}
+	return l
+}
+
+// Save writes fs's state for id, atomically: it writes to a temporary file
+// in dir and renames it into place, so a crash mid-write never leaves a
+// corrupt or partial state file.
 func (s *FileFlowStateStore) Save(ctx context.Context, id string, fs base.FlowStater) error {
+	l := s.lockFor(id)
+	l.Lock()
+	defer l.Unlock()
+
 	data, err := fs.ToJSON()
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filepath.Join(s.dir, base.Clean(id)), data, 0666)
+
+	dest := filepath.Join(s.dir, base.Clean(id))
+	tmp, err := os.CreateTemp(s.dir, base.Clean(id)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return err
+	}
+
+	return s.evict()
 }
 
 func (s *FileFlowStateStore) Load(ctx context.Context, id string, pfs any) error {
 	return base.ReadJSONFile(filepath.Join(s.dir, base.Clean(id)), pfs)
 }
+
+// flowStateSummary is the subset of a saved flow state the backends need to
+// implement List and eviction without the caller's concrete state type.
+// Completion lives under the "operation" object in the serialized
+// base.FlowState, not at the top level.
+type flowStateSummary struct {
+	Operation struct {
+		Done bool `json:"done"`
+	} `json:"operation"`
+}
+
+func (s flowStateSummary) done() bool { return s.Operation.Done }
+
+type fileEntry struct {
+	id      string
+	path    string
+	modTime time.Time
+	done    bool
+}
+
+func (s *FileFlowStateStore) entries() ([]fileEntry, error) {
+	des, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []fileEntry
+	for _, de := range des {
+		if de.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.dir, de.Name())
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+
+		var summary flowStateSummary
+		if data, err := os.ReadFile(path); err == nil {
+			_ = json.Unmarshal(data, &summary) // best-effort; non-JSON or unrecognized files just report done=false
+		}
+
+		entries = append(entries, fileEntry{
+			id:      de.Name(),
+			path:    path,
+			modTime: info.ModTime(),
+			done:    summary.done(),
+		})
+	}
+	return entries, nil
+}
+
+// List returns the ids of flow states matching filter, most recently
+// updated first.
+func (s *FileFlowStateStore) List(ctx context.Context, filter FlowStateFilter) ([]string, error) {
+	entries, err := s.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.After(entries[j].modTime) })
+
+	var ids []string
+	for _, e := range entries {
+		if filter.Completed != nil && e.done != *filter.Completed {
+			continue
+		}
+		ids = append(ids, e.id)
+		if filter.Limit > 0 && len(ids) >= filter.Limit {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// evict removes states older than s.policy.TTL and, if s.policy.MaxEntries
+// is set, the least recently updated states beyond that count. It is called
+// after every Save, so stores left running for a long time stay bounded
+// without a separate background sweep.
+func (s *FileFlowStateStore) evict() error {
+	if s.policy.TTL == 0 && s.policy.MaxEntries == 0 {
+		return nil
+	}
+
+	entries, err := s.entries()
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.After(entries[j].modTime) })
+
+	now := time.Now()
+	for i, e := range entries {
+		expired := s.policy.expired(e.modTime, now)
+		overCap := s.policy.MaxEntries > 0 && i >= s.policy.MaxEntries
+		if expired || overCap {
+			if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/firebase/genkit/go/internal/base"
+)
+
+// A FlowStateStore stores the state of in-progress and completed flow runs
+// so they can be resumed or inspected later, e.g. by the dev UI. Genkit
+// ships FileFlowStateStore, SQLFlowStateStore, and ObjectStoreFlowStateStore;
+// register the one you want with genkit.WithFlowStateStore.
+type FlowStateStore interface {
+	// Save writes the state for the flow run with the given id, overwriting
+	// any previous state for that id.
+	Save(ctx context.Context, id string, fs base.FlowStater) error
+	// Load reads the state for the flow run with the given id into pfs.
+	Load(ctx context.Context, id string, pfs any) error
+	// List returns the ids of flow states matching filter, most recently
+	// updated first.
+	List(ctx context.Context, filter FlowStateFilter) ([]string, error)
+}
+
+// FlowStateFilter restricts the results of FlowStateStore.List.
+type FlowStateFilter struct {
+	// Completed, if non-nil, restricts results to completed (true) or
+	// still-running (false) flow states.
+	Completed *bool
+	// Limit caps the number of ids returned. Zero means no limit.
+	Limit int
+}
+
+// EvictionPolicy bounds how long, and how many, flow states a FlowStateStore
+// retains.
+type EvictionPolicy struct {
+	// TTL removes states that haven't been saved in this long. Zero
+	// disables TTL-based eviction.
+	TTL time.Duration
+	// MaxEntries caps the number of states a store retains, evicting the
+	// least recently updated entries first once the cap is exceeded. Zero
+	// disables the cap.
+	MaxEntries int
+}
+
+// expired reports whether a state last updated at updatedAt should be
+// evicted under p.
+func (p EvictionPolicy) expired(updatedAt time.Time, now time.Time) bool {
+	return p.TTL > 0 && now.Sub(updatedAt) > p.TTL
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"gocloud.dev/blob"
+
+	"github.com/firebase/genkit/go/internal/base"
+)
+
+// ObjectStoreFlowStateStoreOptions configures an ObjectStoreFlowStateStore.
+type ObjectStoreFlowStateStoreOptions struct {
+	// Prefix is prepended to every object key, e.g. "flow-states/". Empty
+	// means no prefix.
+	Prefix string
+	// ServerSideEncryption, if set, is passed through as the "x-amz-server-side-encryption"
+	// header on every write to a bucket opened with the S3 driver. Buckets
+	// opened on other providers ignore it.
+	ServerSideEncryption string
+	// Policy bounds how long, and how many, states the store retains.
+	Policy EvictionPolicy
+}
+
+// ObjectStoreFlowStateStore is a FlowStateStore backed by any S3-compatible
+// object storage bucket opened with gocloud.dev/blob (S3, GCS, Azure Blob,
+// and local/in-memory buckets for tests all work through the same
+// *blob.Bucket).
+type ObjectStoreFlowStateStore struct {
+	bucket *blob.Bucket
+	opts   ObjectStoreFlowStateStoreOptions
+}
+
+// NewObjectStoreFlowStateStore returns an ObjectStoreFlowStateStore that
+// stores flow states as objects in bucket, which the caller has already
+// opened (e.g. with blob.OpenBucket(ctx, "s3://my-bucket")).
+func NewObjectStoreFlowStateStore(bucket *blob.Bucket, opts ObjectStoreFlowStateStoreOptions) *ObjectStoreFlowStateStore {
+	return &ObjectStoreFlowStateStore{bucket: bucket, opts: opts}
+}
+
+func (s *ObjectStoreFlowStateStore) key(id string) string {
+	return s.opts.Prefix + base.Clean(id)
+}
+
+func (s *ObjectStoreFlowStateStore) Save(ctx context.Context, id string, fs base.FlowStater) error {
+	data, err := fs.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	var wopts *blob.WriterOptions
+	if s.opts.ServerSideEncryption != "" {
+		wopts = &blob.WriterOptions{
+			BeforeWrite: func(asFunc func(any) bool) error {
+				// asFunc exposes the provider-specific request type; buckets
+				// opened on providers other than S3 don't recognize
+				// *s3.PutObjectInput, so asFunc returns false and the
+				// request is left untouched.
+				var req *s3.PutObjectInput
+				if asFunc(&req) {
+					req.ServerSideEncryption = types.ServerSideEncryption(s.opts.ServerSideEncryption)
+				}
+				return nil
+			},
+		}
+	}
+
+	w, err := s.bucket.NewWriter(ctx, s.key(id), wopts)
+	if err != nil {
+		return fmt.Errorf("core: opening flow state writer for %q: %w", id, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("core: writing flow state %q: %w", id, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("core: closing flow state writer for %q: %w", id, err)
+	}
+
+	return s.evict(ctx)
+}
+
+func (s *ObjectStoreFlowStateStore) Load(ctx context.Context, id string, pfs any) error {
+	r, err := s.bucket.NewReader(ctx, s.key(id), nil)
+	if err != nil {
+		return fmt.Errorf("core: no flow state with id %q: %w", id, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("core: reading flow state %q: %w", id, err)
+	}
+	return json.Unmarshal(data, pfs)
+}
+
+func (s *ObjectStoreFlowStateStore) List(ctx context.Context, filter FlowStateFilter) ([]string, error) {
+	type entry struct {
+		id      string
+		modTime time.Time
+		done    bool
+	}
+	var entries []entry
+
+	iter := s.bucket.List(&blob.ListOptions{Prefix: s.opts.Prefix})
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("core: listing flow states: %w", err)
+		}
+
+		id := strings.TrimPrefix(obj.Key, s.opts.Prefix)
+		done := false
+		if data, err := s.bucket.ReadAll(ctx, obj.Key); err == nil {
+			var summary flowStateSummary
+			_ = json.Unmarshal(data, &summary)
+			done = summary.done()
+		}
+		entries = append(entries, entry{id: id, modTime: obj.ModTime, done: done})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.After(entries[j].modTime) })
+
+	var ids []string
+	for _, e := range entries {
+		if filter.Completed != nil && e.done != *filter.Completed {
+			continue
+		}
+		ids = append(ids, e.id)
+		if filter.Limit > 0 && len(ids) >= filter.Limit {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// evict deletes objects older than s.opts.Policy.TTL and, if
+// s.opts.Policy.MaxEntries is set, the least recently modified objects
+// beyond that count.
+func (s *ObjectStoreFlowStateStore) evict(ctx context.Context) error {
+	if s.opts.Policy.TTL == 0 && s.opts.Policy.MaxEntries == 0 {
+		return nil
+	}
+
+	type entry struct {
+		key     string
+		modTime time.Time
+	}
+	var entries []entry
+
+	iter := s.bucket.List(&blob.ListOptions{Prefix: s.opts.Prefix})
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("core: evicting flow states: %w", err)
+		}
+		entries = append(entries, entry{key: obj.Key, modTime: obj.ModTime})
+	}
+
+	now := time.Now()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.After(entries[j].modTime) })
+	for i, e := range entries {
+		expired := s.opts.Policy.expired(e.modTime, now)
+		overCap := s.opts.Policy.MaxEntries > 0 && i >= s.opts.Policy.MaxEntries
+		if expired || overCap {
+			if err := s.bucket.Delete(ctx, e.key); err != nil {
+				return fmt.Errorf("core: deleting expired flow state %q: %w", e.key, err)
+			}
+		}
+	}
+	return nil
+}
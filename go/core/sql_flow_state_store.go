@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/firebase/genkit/go/internal/base"
+)
+
+// sqlSchema creates the table SQLFlowStateStore uses if it does not already
+// exist.
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS genkit_flow_states (
+	id TEXT PRIMARY KEY,
+	state BLOB NOT NULL,
+	done BOOLEAN NOT NULL DEFAULT FALSE,
+	updated_at TIMESTAMP NOT NULL,
+	expires_at TIMESTAMP
+)`
+
+// SQLFlowStateStore is a FlowStateStore backed by any database/sql driver.
+// Callers open and configure the *sql.DB (including registering the
+// driver); SQLFlowStateStore only issues queries against it.
+type SQLFlowStateStore struct {
+	db     *sql.DB
+	policy EvictionPolicy
+}
+
+// NewSQLFlowStateStore returns a SQLFlowStateStore that stores flow states
+// in db, creating the genkit_flow_states table if it does not exist.
+//
+// The queries SQLFlowStateStore issues use the "?" placeholder style and an
+// "ON CONFLICT ... DO UPDATE" upsert, so db's driver must be one that
+// accepts both, such as sqlite3. A "$N"-placeholder driver like pgx, or a
+// driver whose upsert syntax differs, such as mysql's "ON DUPLICATE KEY
+// UPDATE", is not supported; wrap db or adapt the queries before using
+// SQLFlowStateStore with one.
+func NewSQLFlowStateStore(ctx context.Context, db *sql.DB, policy EvictionPolicy) (*SQLFlowStateStore, error) {
+	if _, err := db.ExecContext(ctx, sqlSchema); err != nil {
+		return nil, fmt.Errorf("core: creating flow state table: %w", err)
+	}
+	return &SQLFlowStateStore{db: db, policy: policy}, nil
+}
+
+func (s *SQLFlowStateStore) Save(ctx context.Context, id string, fs base.FlowStater) error {
+	data, err := fs.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	var summary flowStateSummary
+	_ = json.Unmarshal(data, &summary) // best-effort; unrecognized shapes just report done=false
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if s.policy.TTL > 0 {
+		e := now.Add(s.policy.TTL)
+		expiresAt = &e
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO genkit_flow_states (id, state, done, updated_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			state = excluded.state,
+			done = excluded.done,
+			updated_at = excluded.updated_at,
+			expires_at = excluded.expires_at`,
+		id, data, summary.done(), now, expiresAt)
+	if err != nil {
+		return fmt.Errorf("core: saving flow state %q: %w", id, err)
+	}
+
+	return s.evict(ctx)
+}
+
+func (s *SQLFlowStateStore) Load(ctx context.Context, id string, pfs any) error {
+	var data []byte
+	var expiresAt *time.Time
+	err := s.db.QueryRowContext(ctx,
+		`SELECT state, expires_at FROM genkit_flow_states WHERE id = ?`, id,
+	).Scan(&data, &expiresAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("core: no flow state with id %q", id)
+	}
+	if err != nil {
+		return fmt.Errorf("core: loading flow state %q: %w", id, err)
+	}
+	if expiresAt != nil && time.Now().After(*expiresAt) {
+		return fmt.Errorf("core: no flow state with id %q", id)
+	}
+
+	return json.Unmarshal(data, pfs)
+}
+
+func (s *SQLFlowStateStore) List(ctx context.Context, filter FlowStateFilter) ([]string, error) {
+	query := `SELECT id FROM genkit_flow_states WHERE (expires_at IS NULL OR expires_at > ?)`
+	args := []any{time.Now()}
+	if filter.Completed != nil {
+		query += ` AND done = ?`
+		args = append(args, *filter.Completed)
+	}
+	query += ` ORDER BY updated_at DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("core: listing flow states: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// evict removes expired rows and, if s.policy.MaxEntries is set, the least
+// recently updated rows beyond that count.
+func (s *SQLFlowStateStore) evict(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM genkit_flow_states WHERE expires_at IS NOT NULL AND expires_at <= ?`, time.Now(),
+	); err != nil {
+		return fmt.Errorf("core: evicting expired flow states: %w", err)
+	}
+
+	if s.policy.MaxEntries <= 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM genkit_flow_states WHERE id NOT IN (
+			SELECT id FROM genkit_flow_states ORDER BY updated_at DESC LIMIT ?
+		)`, s.policy.MaxEntries)
+	if err != nil {
+		return fmt.Errorf("core: enforcing max flow state entries: %w", err)
+	}
+	return nil
+}
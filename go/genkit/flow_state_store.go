@@ -0,0 +1,28 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package genkit
+
+import "github.com/firebase/genkit/go/core"
+
+// WithFlowStateStore returns a GenkitOption that makes Init use store to
+// save and load flow state, instead of the default FileFlowStateStore.
+func WithFlowStateStore(store core.FlowStateStore) GenkitOption {
+	return genkitOptionFunc(func(g *Genkit) error {
+		g.flowStateStore = store
+		return nil
+	})
+}
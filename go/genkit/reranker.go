@@ -0,0 +1,42 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package genkit
+
+import (
+	"github.com/firebase/genkit/go/ai"
+	"github.com/firebase/genkit/go/core"
+)
+
+// DefineReranker registers rerank as a core.Action in g's registry, the
+// same action path DefineRetriever uses, so the dev UI and LookupAction can
+// discover and invoke it by its provider+"/"+name key. It returns an
+// ai.Reranker backed by that action.
+func DefineReranker(g *Genkit, provider, name string, opts *ai.RerankerOptions, rerank ai.RerankFunc) (ai.Reranker, error) {
+	var metadata map[string]any
+	if opts != nil {
+		metadata = map[string]any{
+			"configSchema": opts.ConfigSchema,
+			"info":         opts.Info,
+		}
+	}
+
+	action, err := core.DefineAction(g.reg, provider, name, core.ActionTypeReranker, metadata, rerank)
+	if err != nil {
+		return nil, err
+	}
+	return ai.NewReranker(provider, name, opts, action.Run), nil
+}
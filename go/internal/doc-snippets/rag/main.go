@@ -20,10 +20,12 @@ import (
 	"context"
 	"io"
 	"log"
+	"time"
 
 	"github.com/firebase/genkit/go/ai"
 	"github.com/firebase/genkit/go/genkit"
 	"github.com/firebase/genkit/go/plugins/googlegenai"
+	"github.com/firebase/genkit/go/plugins/ingest"
 	"github.com/firebase/genkit/go/plugins/localvec"
 
 	// "github.com/ledongthuc/pdf"
@@ -116,6 +118,32 @@ func main() {
 	)
 	// [END indexflow]
 
+	// [START indexflowdir]
+	// indexMenuDir indexes every PDF in a directory, preserving page and
+	// heading metadata so retrievers can filter or cite by it, and keeps
+	// indexing new files as they're added to the directory.
+	genkit.DefineFlow(
+		g,
+		"indexMenuDir",
+		func(ctx context.Context, dir string) (any, error) {
+			loader := ingest.NewDirectoryLoader(dir)
+			updates, err := loader.Watch(ctx, 30*time.Second)
+			if err != nil {
+				return nil, err
+			}
+			for upd := range updates {
+				if upd.Err != nil {
+					return nil, upd.Err
+				}
+				if err := localvec.Index(ctx, upd.Docs, docStore); err != nil {
+					return nil, err
+				}
+			}
+			return nil, ctx.Err()
+		},
+	)
+	// [END indexflowdir]
+
 	<-ctx.Done()
 }
 
@@ -238,11 +266,13 @@ func customret() {
 		},
 	}
 
+	embedder := googlegenai.VertexAIEmbedder(g, "text-embedding-004")
+
 	_, menuPDFRetriever, _ := localvec.DefineRetriever(
 		g,
 		"menuQA",
 		localvec.Config{
-			Embedder: googlegenai.VertexAIEmbedder(g, "text-embedding-004"),
+			Embedder: embedder,
 		},
 		retOpts,
 	)
@@ -288,7 +318,10 @@ func customret() {
 			}
 
 			// Re-rank the returned documents using your custom function.
-			rerankedDocs := rerank(response.Documents)
+			rerankedDocs, err := rerank(ctx, embedder, req.Query, response.Documents)
+			if err != nil {
+				return nil, err
+			}
 			response.Documents = rerankedDocs[:opts.K]
 
 			return response, nil
@@ -299,6 +332,78 @@ func customret() {
 	_ = advancedMenuRetriever
 }
 
-func rerank(document []*ai.Document) []*ai.Document {
-	panic("unimplemented")
+// rerank reorders documents by relevance to query, using Maximal Marginal
+// Relevance to keep the top results diverse rather than several near-
+// duplicate chunks.
+func rerank(ctx context.Context, embedder ai.Embedder, query *ai.Document, documents []*ai.Document) ([]*ai.Document, error) {
+	resp, err := ai.NewMMRReranker(embedder).Rerank(ctx, &ai.RerankerRequest{
+		Query:     query,
+		Documents: documents,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Documents, nil
+}
+
+func hybridRetrieval() {
+	ctx := context.Background()
+	g, err := genkit.Init(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = (&googlegenai.VertexAI{}).Init(ctx, g)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = localvec.Init()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	retOpts := &ai.RetrieverOptions{
+		ConfigSchema: localvec.RetrieverOptions{},
+		Info: &ai.RetrieverInfo{
+			Label: "menuQA",
+			Supports: &ai.RetrieverSupports{
+				Media: false,
+			},
+		},
+	}
+	_, vectorRetriever, err := localvec.DefineRetriever(
+		g,
+		"menuQA",
+		localvec.Config{
+			Embedder: googlegenai.VertexAIEmbedder(g, "text-embedding-004"),
+		},
+		retOpts,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// bm25Retriever is any other ai.Retriever that searches the same corpus
+	// a different way, e.g. a keyword index.
+	var bm25Retriever ai.Retriever
+
+	// [START hybridret]
+	hybridRetriever := ai.NewHybridRetriever(ai.HybridRetrieverOptions{
+		Retrievers: []ai.Retriever{vectorRetriever, bm25Retriever},
+		Reranker:   ai.NewRRFReranker(),
+		K:          5,
+	})
+
+	genkit.DefineFlow(
+		g,
+		"menuQAHybrid",
+		func(ctx context.Context, question string) (*ai.RetrieverResponse, error) {
+			return hybridRetriever.Retrieve(ctx, &ai.RetrieverRequest{
+				Query: ai.DocumentFromText(question, nil),
+			})
+		},
+	)
+	// [END hybridret]
+
+	<-ctx.Done()
 }
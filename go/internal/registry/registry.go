@@ -17,6 +17,7 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -34,20 +35,38 @@ const (
 	PromptDirKey    = "genkit/promptDir"
 )
 
+// ErrAlreadyRegistered is returned by RegisterAction when key is already
+// registered to a value that is not Equal to the new one.
+var ErrAlreadyRegistered = errors.New("registry: action already registered")
+
+// An Equaler reports whether two values are semantically equal. An action
+// that implements it makes RegisterAction idempotent: re-registering an
+// Equal value is a no-op instead of ErrAlreadyRegistered, so plugin init
+// can be retried safely.
+type Equaler interface {
+	Equal(other any) bool
+}
+
 type Registry struct {
 	tstate    *tracing.State
 	mu        sync.Mutex
-	actions   map[string]any // Values follow interface core.Action but we can't reference it here.
-	plugins   map[string]any // Values follow interface genkit.Plugin but we can't reference it here.
-	values    map[string]any // Values can truly be anything.
+	actions   map[string]any               // Values follow interface core.Action but we can't reference it here.
+	plugins   map[string]any               // Values follow interface genkit.Plugin but we can't reference it here.
+	values    map[string]any               // Values can truly be anything.
+	versions  map[string]map[string]string // base action key -> version -> full versioned key
+	latest    map[string]string            // base action key -> latest registered version
+	watchers  map[string][]chan Event
 	Dotprompt *dotprompt.Dotprompt
 }
 
 func New() (*Registry, error) {
 	r := &Registry{
-		actions: map[string]any{},
-		plugins: map[string]any{},
-		values:  map[string]any{},
+		actions:  map[string]any{},
+		plugins:  map[string]any{},
+		values:   map[string]any{},
+		versions: map[string]map[string]string{},
+		latest:   map[string]string{},
+		watchers: map[string][]chan Event{},
 	}
 	r.tstate = tracing.NewState()
 	if os.Getenv("GENKIT_TELEMETRY_SERVER") != "" {
@@ -76,16 +95,88 @@ func (r *Registry) RegisterPlugin(name string, p any) {
 }
 
 // RegisterAction records the action in the registry.
-// It panics if an action with the same type, provider and name is already
-// registered.
-func (r *Registry) RegisterAction(key string, action any) {
+// If an action is already registered under key, RegisterAction is a no-op
+// when action implements Equaler and reports itself Equal to the existing
+// one; otherwise it returns ErrAlreadyRegistered. Use ReplaceAction to
+// intentionally overwrite an existing registration, e.g. for hot-reloading.
+//
+// key may carry a version suffix ("provider/name@v1.2.0") to register one
+// of several versions of an action under the same base key; look them up
+// with LookupActionVersion.
+func (r *Registry) RegisterAction(key string, action any) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if _, ok := r.actions[key]; ok {
-		panic(fmt.Sprintf("action %q is already registered", key))
+	if existing, ok := r.actions[key]; ok {
+		if eq, ok := action.(Equaler); ok && eq.Equal(existing) {
+			return nil
+		}
+		return fmt.Errorf("%w: %q", ErrAlreadyRegistered, key)
 	}
 	r.actions[key] = action
+	r.recordVersionLocked(key)
 	slog.Debug("RegisterAction", "key", key)
+	r.notifyLocked(key, EventRegistered)
+	return nil
+}
+
+// ReplaceAction registers action under key whether or not key is already
+// registered, unlike RegisterAction. Watchers of key receive EventReplaced
+// rather than EventRegistered if a previous value is overwritten. Use this
+// for dev-mode hot-reloading: swapping in a re-parsed dotprompt or a
+// rebuilt retriever without restarting the process.
+func (r *Registry) ReplaceAction(key string, action any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, existed := r.actions[key]
+	r.actions[key] = action
+	r.recordVersionLocked(key)
+	if existed {
+		slog.Debug("ReplaceAction", "key", key)
+		r.notifyLocked(key, EventReplaced)
+	} else {
+		slog.Debug("RegisterAction", "key", key)
+		r.notifyLocked(key, EventRegistered)
+	}
+}
+
+// UnregisterAction removes the action registered under key. It returns an
+// error, rather than panicking, if key is not registered, so that callers
+// like the dev reloader can treat a missing action as a recoverable
+// condition.
+func (r *Registry) UnregisterAction(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.actions[key]; !ok {
+		return fmt.Errorf("registry: no action %q to unregister", key)
+	}
+	delete(r.actions, key)
+	if base, version, ok := splitVersionedKey(key); ok {
+		delete(r.versions[base], version)
+		if r.latest[base] == version {
+			delete(r.latest, base)
+			for v := range r.versions[base] {
+				if latest, ok := r.latest[base]; !ok || compareVersions(v, latest) > 0 {
+					r.latest[base] = v
+				}
+			}
+		}
+	}
+	slog.Debug("UnregisterAction", "key", key)
+	r.notifyLocked(key, EventUnregistered)
+	return nil
+}
+
+// UnregisterPlugin removes the plugin registered under name. It returns an
+// error, rather than panicking, if name is not registered.
+func (r *Registry) UnregisterPlugin(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.plugins[name]; !ok {
+		return fmt.Errorf("registry: no plugin %q to unregister", name)
+	}
+	delete(r.plugins, name)
+	slog.Debug("UnregisterPlugin", "name", name)
+	return nil
 }
 
 // LookupPlugin returns the plugin for the given name, or nil if there is none.
@@ -105,6 +196,21 @@ func (r *Registry) RegisterValue(name string, value any) {
 	}
 	r.values[name] = value
 	slog.Debug("RegisterValue", "name", name)
+	r.notifyLocked(name, EventRegistered)
+}
+
+// UnregisterValue removes the value registered under name. It returns an
+// error, rather than panicking, if name is not registered.
+func (r *Registry) UnregisterValue(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.values[name]; !ok {
+		return fmt.Errorf("registry: no value %q to unregister", name)
+	}
+	delete(r.values, name)
+	slog.Debug("UnregisterValue", "name", name)
+	r.notifyLocked(name, EventUnregistered)
+	return nil
 }
 
 // LookupValue returns the value for the given name, or nil if there is none.
@@ -205,3 +311,101 @@ func (r *Registry) DefineHelper(name string, fn any) error {
 	r.Dotprompt.Helpers[name] = fn
 	return nil
 }
+
+// LookupActionVersion returns the action registered under base with a
+// version matching constraint, e.g. ">=1.0.0 <2.0.0", "^1.2.0", or
+// "latest" (the default when constraint is empty). base must be the
+// unversioned key ("googleai/gemini-pro"); register versions under it with
+// RegisterAction using a "@vN" suffix ("googleai/gemini-pro@v1.2.0").
+func (r *Registry) LookupActionVersion(base, constraint string) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := r.versions[base]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("registry: no versions registered for %q", base)
+	}
+
+	if constraint == "" || constraint == "latest" {
+		return r.actions[versions[r.latest[base]]], nil
+	}
+
+	want, err := parseVersionConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("registry: %w", err)
+	}
+
+	var best string
+	for v := range versions {
+		if !want.matches(v) {
+			continue
+		}
+		if best == "" || compareVersions(v, best) > 0 {
+			best = v
+		}
+	}
+	if best == "" {
+		return nil, fmt.Errorf("registry: no version of %q satisfies %q", base, constraint)
+	}
+	return r.actions[versions[best]], nil
+}
+
+// recordVersionLocked updates r.versions and r.latest if key carries a
+// version suffix. r.mu must be held.
+func (r *Registry) recordVersionLocked(key string) {
+	base, version, ok := splitVersionedKey(key)
+	if !ok {
+		return
+	}
+	if r.versions[base] == nil {
+		r.versions[base] = map[string]string{}
+	}
+	r.versions[base][version] = key
+	if latest, ok := r.latest[base]; !ok || compareVersions(version, latest) > 0 {
+		r.latest[base] = version
+	}
+}
+
+// An EventType describes what happened to a registry key.
+type EventType int
+
+const (
+	// EventRegistered fires when a key is registered for the first time.
+	EventRegistered EventType = iota
+	// EventUnregistered fires when a key is unregistered.
+	EventUnregistered
+	// EventReplaced fires when ReplaceAction overwrites an existing key.
+	EventReplaced
+)
+
+// An Event reports a register, unregister, or replace for a key being
+// watched via Registry.Watch.
+type Event struct {
+	Type EventType
+	Key  string
+}
+
+// Watch returns a channel that receives an Event whenever key is
+// registered, unregistered, or replaced, so a dev-mode reloader can swap in
+// a re-parsed dotprompt or a rebuilt retriever without restarting the
+// process. The channel is buffered; if a receiver falls behind, further
+// events for key are dropped rather than blocking the register/unregister
+// call that produced them.
+func (r *Registry) Watch(key string) <-chan Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch := make(chan Event, 1)
+	r.watchers[key] = append(r.watchers[key], ch)
+	return ch
+}
+
+// notifyLocked sends an Event to every channel watching key. r.mu must be
+// held.
+func (r *Registry) notifyLocked(key string, t EventType) {
+	for _, ch := range r.watchers[key] {
+		select {
+		case ch <- Event{Type: t, Key: key}:
+		default:
+		}
+	}
+}
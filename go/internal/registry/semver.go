@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionSuffix matches a trailing "@vN" version tag on an action key, as
+// in "googleai/gemini-pro@v1.2.0".
+var versionSuffix = regexp.MustCompile(`^(.+)@v(\d+(?:\.\d+){0,2})$`)
+
+// splitVersionedKey splits a registry key into its unversioned base and
+// version, if it carries a "@vN" suffix.
+func splitVersionedKey(key string) (base, version string, ok bool) {
+	m := versionSuffix.FindStringSubmatch(key)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// parseSemver parses a (possibly partial) "major[.minor[.patch]]" version
+// into three ints, defaulting missing components to 0.
+func parseSemver(v string) ([3]int, error) {
+	var out [3]int
+	parts := strings.SplitN(v, ".", 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return out, fmt.Errorf("invalid version %q", v)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// compareVersions returns -1, 0, or 1 as a is less than, equal to, or
+// greater than b. Invalid versions sort before valid ones.
+func compareVersions(a, b string) int {
+	va, errA := parseSemver(a)
+	vb, errB := parseSemver(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	for i := range va {
+		switch {
+		case va[i] < vb[i]:
+			return -1
+		case va[i] > vb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// an operator is one clause of a version constraint, e.g. ">=1.2.0".
+type operator struct {
+	op      string
+	version string
+}
+
+func (o operator) matches(v string) bool {
+	c := compareVersions(v, o.version)
+	switch o.op {
+	case "=":
+		return c == 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case "^":
+		// ^1.2.3 allows any version with the same major (or, for a 0.x.y
+		// version, the same minor) that is >= the given version.
+		want, err := parseSemver(o.version)
+		if err != nil {
+			return false
+		}
+		got, err := parseSemver(v)
+		if err != nil {
+			return false
+		}
+		if want[0] > 0 {
+			return got[0] == want[0] && compareVersions(v, o.version) >= 0
+		}
+		return got[0] == 0 && got[1] == want[1] && compareVersions(v, o.version) >= 0
+	case "~":
+		// ~1.2.3 allows any version with the same major.minor that is >=
+		// the given version.
+		want, err := parseSemver(o.version)
+		if err != nil {
+			return false
+		}
+		got, err := parseSemver(v)
+		if err != nil {
+			return false
+		}
+		return got[0] == want[0] && got[1] == want[1] && compareVersions(v, o.version) >= 0
+	default:
+		return false
+	}
+}
+
+// versionConstraint is a space-separated, ANDed list of operators, e.g.
+// ">=1.0.0 <2.0.0".
+type versionConstraint []operator
+
+func (c versionConstraint) matches(v string) bool {
+	for _, op := range c {
+		if !op.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+var constraintClause = regexp.MustCompile(`^(=|>=|<=|>|<|\^|~)?(\d+(?:\.\d+){0,2})$`)
+
+// parseVersionConstraint parses a semver range such as ">=1.0.0 <2.0.0",
+// "^1.2.0", "~1.2.0", or "1.2.3" (an implicit "=").
+func parseVersionConstraint(s string) (versionConstraint, error) {
+	var c versionConstraint
+	for _, clause := range strings.Fields(s) {
+		m := constraintClause.FindStringSubmatch(clause)
+		if m == nil {
+			return nil, fmt.Errorf("invalid version constraint clause %q", clause)
+		}
+		op := m[1]
+		if op == "" {
+			op = "="
+		}
+		c = append(c, operator{op: op, version: m[2]})
+	}
+	if len(c) == 0 {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+	return c, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+// DirectoryLoader is a DocumentLoader that loads every file matching Glob
+// under Dir.
+type DirectoryLoader struct {
+	// Dir is the directory to scan.
+	Dir string
+	// Glob filters files within Dir, e.g. "*.pdf". Defaults to "*.pdf".
+	Glob string
+	// NewLoader builds the DocumentLoader for a single matched file.
+	// Defaults to NewPDFLoader.
+	NewLoader func(path string) DocumentLoader
+}
+
+// NewDirectoryLoader returns a DirectoryLoader that loads every PDF in dir.
+func NewDirectoryLoader(dir string) *DirectoryLoader {
+	return &DirectoryLoader{Dir: dir, Glob: "*.pdf"}
+}
+
+func (l *DirectoryLoader) newLoader(path string) DocumentLoader {
+	if l.NewLoader != nil {
+		return l.NewLoader(path)
+	}
+	return NewPDFLoader(path)
+}
+
+func (l *DirectoryLoader) matches() ([]string, error) {
+	glob := l.Glob
+	if glob == "" {
+		glob = "*.pdf"
+	}
+	paths, err := filepath.Glob(filepath.Join(l.Dir, glob))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (l *DirectoryLoader) Load(ctx context.Context) ([]*ai.Document, error) {
+	paths, err := l.matches()
+	if err != nil {
+		return nil, fmt.Errorf("ingest: listing %s: %w", l.Dir, err)
+	}
+
+	var docs []*ai.Document
+	for _, path := range paths {
+		d, err := l.newLoader(path).Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, d...)
+	}
+	return docs, nil
+}
+
+// DirectoryUpdate is one item sent on the channel returned by Watch: either
+// the documents loaded from a new or changed file, or the error that made a
+// poll fail. Err is set only for a failed poll; otherwise Docs holds the
+// file's documents, which may be none.
+type DirectoryUpdate struct {
+	Docs []*ai.Document
+	Err  error
+}
+
+// Watch polls Dir every interval and sends the documents loaded from any
+// file that is new or has changed since the previous poll, so a
+// long-running indexing flow can pick up additions to Dir incrementally
+// instead of re-scanning the whole directory. The first poll starts in the
+// background as soon as Watch returns, and each update is sent only when
+// the caller receives it; Watch itself returns immediately after a cheap
+// validation of Dir and Glob. It stops, and closes the channel, when
+// ctx is canceled; if a poll fails, it sends a DirectoryUpdate carrying the
+// error and then stops, so callers can tell a failed poll apart from ctx
+// cancellation instead of seeing the channel close silently.
+func (l *DirectoryLoader) Watch(ctx context.Context, interval time.Duration) (<-chan DirectoryUpdate, error) {
+	if _, err := l.matches(); err != nil {
+		return nil, fmt.Errorf("ingest: listing %s: %w", l.Dir, err)
+	}
+
+	out := make(chan DirectoryUpdate)
+	seen := map[string]time.Time{}
+
+	send := func(u DirectoryUpdate) bool {
+		select {
+		case out <- u:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	poll := func() bool {
+		paths, err := l.matches()
+		if err != nil {
+			send(DirectoryUpdate{Err: err})
+			return false
+		}
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				send(DirectoryUpdate{Err: err})
+				return false
+			}
+			if mtime, ok := seen[path]; ok && !info.ModTime().After(mtime) {
+				continue
+			}
+			seen[path] = info.ModTime()
+
+			docs, err := l.newLoader(path).Load(ctx)
+			if err != nil {
+				send(DirectoryUpdate{Err: err})
+				return false
+			}
+			if !send(DirectoryUpdate{Docs: docs}) {
+				return false
+			}
+		}
+		return true
+	}
+
+	go func() {
+		defer close(out)
+		if !poll() {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !poll() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ingest loads source material into the []*ai.Document shape used
+// throughout genkit's RAG flows, preserving the structure (pages, headings,
+// figures) that a single plain-text blob would discard.
+package ingest
+
+import (
+	"context"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+// A DocumentLoader reads documents from some source — a single file, a
+// directory, a remote bucket — for indexing. Implementations should
+// populate ai.Document.Metadata with enough provenance (source path, page
+// number, section) that a retriever can filter or cite by it later.
+type DocumentLoader interface {
+	Load(ctx context.Context) ([]*ai.Document, error)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ingest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+	"github.com/ledongthuc/pdf"
+)
+
+// Rasterizer renders a single page of a PDF to an image, for indexing
+// figures and scanned content that text extraction alone would miss. page is
+// 1-based. The returned contentType is a MIME type such as "image/png".
+type Rasterizer func(path string, page int) (contentType string, data []byte, err error)
+
+// PDFLoader is a DocumentLoader that reads a PDF and yields one ai.Document
+// per page instead of collapsing the whole file to one text blob. Each
+// document's Metadata carries the source path, page number, and a
+// best-effort heading derived from the largest font on the page. If
+// Rasterizer is set, PDFLoader also yields a media document per page so a
+// multimodal embedder can index figures alongside the text.
+type PDFLoader struct {
+	// Path is the PDF file to load.
+	Path string
+	// Rasterizer renders page images for multimodal indexing. If nil, only
+	// text documents are produced.
+	Rasterizer Rasterizer
+}
+
+// NewPDFLoader returns a PDFLoader for the PDF at path.
+func NewPDFLoader(path string) *PDFLoader {
+	return &PDFLoader{Path: path}
+}
+
+func (l *PDFLoader) Load(ctx context.Context) ([]*ai.Document, error) {
+	f, r, err := pdf.Open(l.Path)
+	if f != nil {
+		defer f.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ingest: opening %s: %w", l.Path, err)
+	}
+
+	var docs []*ai.Document
+	for page := 1; page <= r.NumPage(); page++ {
+		p := r.Page(page)
+		if p.V.IsNull() {
+			continue
+		}
+
+		text, heading, err := extractPageText(p)
+		if err != nil {
+			return nil, fmt.Errorf("ingest: reading page %d of %s: %w", page, l.Path, err)
+		}
+
+		metadata := map[string]any{
+			"source":  l.Path,
+			"page":    page,
+			"heading": heading,
+		}
+		if text != "" {
+			docs = append(docs, ai.DocumentFromText(text, metadata))
+		}
+
+		if l.Rasterizer != nil {
+			contentType, data, err := l.Rasterizer(l.Path, page)
+			if err != nil {
+				return nil, fmt.Errorf("ingest: rasterizing page %d of %s: %w", page, l.Path, err)
+			}
+			dataURI := fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data))
+			docs = append(docs, ai.DocumentFromMedia(contentType, dataURI, metadata))
+		}
+	}
+	return docs, nil
+}
+
+// extractPageText returns a page's plain text along with a best-effort
+// heading: the text of the line with the largest font size on the page.
+func extractPageText(p pdf.Page) (text, heading string, err error) {
+	rows, err := p.GetTextByRow()
+	if err != nil {
+		return "", "", err
+	}
+
+	var maxSize float64
+	var headingLine string
+	var body strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		var rowMaxSize float64
+		for _, ch := range row.Content {
+			line.WriteString(ch.S)
+			if ch.FontSize > rowMaxSize {
+				rowMaxSize = ch.FontSize
+			}
+		}
+		if rowMaxSize > maxSize {
+			maxSize = rowMaxSize
+			headingLine = strings.TrimSpace(line.String())
+		}
+		body.WriteString(line.String())
+		body.WriteString("\n")
+	}
+	return body.String(), headingLine, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import "strings"
+
+// MarkdownSplitterOption configures a MarkdownSplitter.
+type MarkdownSplitterOption func(*MarkdownSplitter)
+
+// WithMarkdownChunkSize sets the approximate maximum chunk size in runes.
+// Sections under a single heading larger than this are further split at
+// blank-line paragraph breaks. Default 1000.
+func WithMarkdownChunkSize(n int) MarkdownSplitterOption {
+	return func(s *MarkdownSplitter) { s.chunkSize = n }
+}
+
+// MarkdownSplitter splits markdown into chunks along heading boundaries. It
+// prefixes each chunk with its ATX heading path (e.g. "Intro > Setup") so
+// downstream consumers don't lose the section context a plain character
+// split would discard, and it never splits inside a fenced code block.
+type MarkdownSplitter struct {
+	chunkSize int
+}
+
+// NewMarkdownSplitter returns a MarkdownSplitter.
+func NewMarkdownSplitter(opts ...MarkdownSplitterOption) *MarkdownSplitter {
+	s := &MarkdownSplitter{chunkSize: 1000}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *MarkdownSplitter) SplitText(text string) ([]string, error) {
+	lines := strings.Split(text, "\n")
+	var chunks []string
+	var headingPath []string
+	var body strings.Builder
+	inFence := false
+
+	flush := func() {
+		trimmed := strings.TrimSpace(body.String())
+		body.Reset()
+		if trimmed == "" {
+			return
+		}
+		if len(headingPath) > 0 {
+			chunks = append(chunks, strings.Join(headingPath, " > ")+"\n\n"+trimmed)
+		} else {
+			chunks = append(chunks, trimmed)
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			body.WriteString(line)
+			body.WriteString("\n")
+			continue
+		}
+		if !inFence {
+			if level, title, ok := parseHeading(line); ok {
+				flush()
+				if level > len(headingPath)+1 {
+					level = len(headingPath) + 1
+				}
+				headingPath = append(headingPath[:level-1], title)
+				continue
+			}
+		}
+		body.WriteString(line)
+		body.WriteString("\n")
+		if !inFence && trimmed == "" && len([]rune(body.String())) > s.chunkSize {
+			flush()
+		}
+	}
+	flush()
+	return chunks, nil
+}
+
+// parseHeading reports whether line is an ATX heading ("# Title") and, if
+// so, its level and title.
+func parseHeading(line string) (level int, title string, ok bool) {
+	rest := strings.TrimLeft(line, "#")
+	level = len(line) - len(rest)
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	if rest != "" && !strings.HasPrefix(rest, " ") {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(rest), true
+}
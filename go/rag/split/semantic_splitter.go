@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/firebase/genkit/go/ai"
+)
+
+var sentenceBoundary = regexp.MustCompile(`(?s)[.!?]+\s+`)
+
+// SemanticSplitterOption configures a SemanticSplitter.
+type SemanticSplitterOption func(*SemanticSplitter)
+
+// WithPercentileThreshold sets the percentile (0-100) of adjacent-sentence
+// distances above which a cut is made. Default 95.
+func WithPercentileThreshold(p float64) SemanticSplitterOption {
+	return func(s *SemanticSplitter) { s.percentile = p }
+}
+
+// WithMinChunkSize sets the minimum chunk length, in runes, below which a
+// fragment is merged into the next chunk instead of standing alone. Default
+// 200.
+func WithMinChunkSize(n int) SemanticSplitterOption {
+	return func(s *SemanticSplitter) { s.minChunkSize = n }
+}
+
+// SemanticSplitter splits text at shifts in meaning rather than at a fixed
+// size: it embeds each sentence with embedder, measures the cosine distance
+// between consecutive sentence embeddings, and cuts wherever that distance
+// is a local maximum at or above the configured percentile of all distances
+// in the text (default 95th). Fragments smaller than MinChunkSize are
+// merged into a neighboring chunk.
+type SemanticSplitter struct {
+	embedder     ai.Embedder
+	percentile   float64
+	minChunkSize int
+}
+
+// NewSemanticSplitter returns a SemanticSplitter that embeds sentences with
+// embedder.
+func NewSemanticSplitter(embedder ai.Embedder, opts ...SemanticSplitterOption) *SemanticSplitter {
+	s := &SemanticSplitter{embedder: embedder, percentile: 95, minChunkSize: 200}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *SemanticSplitter) SplitText(text string) ([]string, error) {
+	sentences := splitSentences(text)
+	if len(sentences) <= 1 {
+		return sentences, nil
+	}
+
+	docs := make([]*ai.Document, len(sentences))
+	for i, sent := range sentences {
+		docs[i] = ai.DocumentFromText(sent, nil)
+	}
+	resp, err := s.embedder.Embed(context.Background(), &ai.EmbedRequest{Input: docs})
+	if err != nil {
+		return nil, fmt.Errorf("split: embedding sentences: %w", err)
+	}
+	if len(resp.Embeddings) != len(sentences) {
+		return nil, fmt.Errorf("split: embedder returned %d embeddings for %d sentences", len(resp.Embeddings), len(sentences))
+	}
+
+	distances := make([]float64, len(sentences)-1)
+	for i := range distances {
+		distances[i] = 1 - cosineSimilarity(resp.Embeddings[i].Embedding, resp.Embeddings[i+1].Embedding)
+	}
+	threshold := percentile(distances, s.percentile)
+
+	var chunks []string
+	var current strings.Builder
+	current.WriteString(sentences[0])
+	for i, d := range distances {
+		isLocalMax := d >= threshold &&
+			(i == 0 || d >= distances[i-1]) &&
+			(i == len(distances)-1 || d >= distances[i+1])
+		if isLocalMax {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		} else {
+			current.WriteString(" ")
+		}
+		current.WriteString(sentences[i+1])
+	}
+	chunks = append(chunks, current.String())
+
+	return mergeSmallChunks(chunks, s.minChunkSize), nil
+}
+
+func splitSentences(text string) []string {
+	var sentences []string
+	last := 0
+	for _, loc := range sentenceBoundary.FindAllStringIndex(text, -1) {
+		sentences = append(sentences, strings.TrimSpace(text[last:loc[1]]))
+		last = loc[1]
+	}
+	if rest := strings.TrimSpace(text[last:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// percentile returns the p-th percentile of values using linear
+// interpolation between closest ranks.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
+
+// mergeSmallChunks folds the chunk that follows any chunk shorter than
+// minSize into it, until the merged chunk reaches minSize; a too-small
+// final chunk is folded into its predecessor instead.
+func mergeSmallChunks(chunks []string, minSize int) []string {
+	var merged []string
+	for _, c := range chunks {
+		if len(merged) > 0 && len([]rune(merged[len(merged)-1])) < minSize {
+			merged[len(merged)-1] += " " + c
+			continue
+		}
+		merged = append(merged, c)
+	}
+	if len(merged) > 1 && len([]rune(merged[len(merged)-1])) < minSize {
+		merged[len(merged)-2] += " " + merged[len(merged)-1]
+		merged = merged[:len(merged)-1]
+	}
+	return merged
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
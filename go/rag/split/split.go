@@ -0,0 +1,29 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package split provides Splitters that break a document's text into
+// chunks for embedding and indexing, beyond plain character-count chunking:
+// TokenSplitter sizes chunks by token count, MarkdownSplitter respects
+// heading and code-fence structure, and SemanticSplitter cuts at shifts in
+// meaning.
+package split
+
+// Splitter breaks text into chunks. Indexing flows accept a Splitter so
+// callers can swap strategies without touching flow logic, the same way
+// textsplitter.NewRecursiveCharacter is used today.
+type Splitter interface {
+	SplitText(text string) ([]string, error)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import "fmt"
+
+// Tokenizer converts between text and the token ids a model actually bills
+// and limits by. Implementations wrap a vocabulary and merge or
+// segmentation rules loaded from elsewhere (e.g. a tiktoken .tiktoken file
+// or a SentencePiece .model); TokenSplitter only needs Encode and Decode.
+type Tokenizer interface {
+	Encode(text string) []int
+	Decode(tokens []int) string
+}
+
+// TokenSplitterOption configures a TokenSplitter.
+type TokenSplitterOption func(*TokenSplitter)
+
+// WithTokenChunkSize sets the number of tokens per chunk. Default 512.
+func WithTokenChunkSize(n int) TokenSplitterOption {
+	return func(s *TokenSplitter) { s.chunkSize = n }
+}
+
+// WithTokenChunkOverlap sets the number of tokens shared between
+// consecutive chunks. Default 0.
+func WithTokenChunkOverlap(n int) TokenSplitterOption {
+	return func(s *TokenSplitter) { s.chunkOverlap = n }
+}
+
+// TokenSplitter splits text into chunks of exactly N tokens (the final
+// chunk may be shorter), with M tokens of overlap between consecutive
+// chunks, as measured by a Tokenizer rather than by character count. This
+// sizes chunks correctly for models that bill or limit context by token
+// count, and avoids splitting mid-token.
+type TokenSplitter struct {
+	tokenizer    Tokenizer
+	chunkSize    int
+	chunkOverlap int
+}
+
+// NewTokenSplitter returns a TokenSplitter that measures chunks with
+// tokenizer.
+func NewTokenSplitter(tokenizer Tokenizer, opts ...TokenSplitterOption) *TokenSplitter {
+	s := &TokenSplitter{tokenizer: tokenizer, chunkSize: 512}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *TokenSplitter) SplitText(text string) ([]string, error) {
+	if s.chunkSize <= 0 {
+		return nil, fmt.Errorf("split: chunk size must be positive, got %d", s.chunkSize)
+	}
+	if s.chunkOverlap >= s.chunkSize {
+		return nil, fmt.Errorf("split: chunk overlap %d must be less than chunk size %d", s.chunkOverlap, s.chunkSize)
+	}
+
+	tokens := s.tokenizer.Encode(text)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	step := s.chunkSize - s.chunkOverlap
+	var chunks []string
+	for start := 0; start < len(tokens); start += step {
+		end := start + s.chunkSize
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		chunks = append(chunks, s.tokenizer.Decode(tokens[start:end]))
+		if end == len(tokens) {
+			break
+		}
+	}
+	return chunks, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package split
+
+import "strings"
+
+// BPEMerge is a single learned byte-pair merge rule: wherever Left is
+// immediately followed by Right, the pair is replaced with Left+Right.
+type BPEMerge struct {
+	Left, Right string
+}
+
+// BPETokenizer is a tiktoken-style byte-pair-encoding Tokenizer. Callers
+// supply the vocabulary and merge rules produced by a BPE trainer (e.g.
+// exported from a .tiktoken file); BPETokenizer only implements the
+// encode/decode algorithm over them.
+type BPETokenizer struct {
+	ranks  map[string]int
+	merges []BPEMerge
+	ids    map[int]string
+}
+
+// NewBPETokenizer returns a Tokenizer that applies merges, in order, to the
+// characters (runes) of its input, then looks up each resulting symbol's id
+// in ranks. Symbols with no id in ranks are dropped.
+func NewBPETokenizer(ranks map[string]int, merges []BPEMerge) *BPETokenizer {
+	ids := make(map[int]string, len(ranks))
+	for tok, id := range ranks {
+		ids[id] = tok
+	}
+	return &BPETokenizer{ranks: ranks, merges: merges, ids: ids}
+}
+
+func (t *BPETokenizer) Encode(text string) []int {
+	symbols := strings.Split(text, "")
+	for _, m := range t.merges {
+		symbols = applyBPEMerge(symbols, m.Left, m.Right)
+	}
+	ids := make([]int, 0, len(symbols))
+	for _, s := range symbols {
+		if id, ok := t.ranks[s]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (t *BPETokenizer) Decode(tokens []int) string {
+	var b strings.Builder
+	for _, id := range tokens {
+		b.WriteString(t.ids[id])
+	}
+	return b.String()
+}
+
+func applyBPEMerge(symbols []string, left, right string) []string {
+	out := make([]string, 0, len(symbols))
+	for i := 0; i < len(symbols); i++ {
+		if i < len(symbols)-1 && symbols[i] == left && symbols[i+1] == right {
+			out = append(out, left+right)
+			i++
+			continue
+		}
+		out = append(out, symbols[i])
+	}
+	return out
+}
+
+// spSpaceMarker is SentencePiece's convention for marking word boundaries,
+// U+2581 LOWER ONE EIGHTH BLOCK.
+const spSpaceMarker = "▁"
+
+// SentencePieceTokenizer is a Tokenizer that segments text into the longest
+// matching pieces of a SentencePiece-style vocabulary, loaded elsewhere
+// (e.g. exported from a .model file). This greedy longest-match
+// segmentation is simpler than SentencePiece's unigram-language-model
+// search and does not use piece scores, so for some inputs it picks
+// different pieces than SentencePiece itself would.
+type SentencePieceTokenizer struct {
+	vocab  map[string]int
+	ids    map[int]string
+	maxLen int
+}
+
+// NewSentencePieceTokenizer returns a Tokenizer over vocab, a map from piece
+// text to token id.
+func NewSentencePieceTokenizer(vocab map[string]int) *SentencePieceTokenizer {
+	ids := make(map[int]string, len(vocab))
+	maxLen := 0
+	for piece, id := range vocab {
+		ids[id] = piece
+		if n := len([]rune(piece)); n > maxLen {
+			maxLen = n
+		}
+	}
+	return &SentencePieceTokenizer{vocab: vocab, ids: ids, maxLen: maxLen}
+}
+
+func (t *SentencePieceTokenizer) Encode(text string) []int {
+	runes := []rune(strings.ReplaceAll(text, " ", spSpaceMarker))
+	var ids []int
+	for i := 0; i < len(runes); {
+		matched := false
+		maxRunes := t.maxLen
+		if maxRunes > len(runes)-i {
+			maxRunes = len(runes) - i
+		}
+		for l := maxRunes; l > 0; l-- {
+			piece := string(runes[i : i+l])
+			if id, ok := t.vocab[piece]; ok {
+				ids = append(ids, id)
+				i += l
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			i++
+		}
+	}
+	return ids
+}
+
+func (t *SentencePieceTokenizer) Decode(tokens []int) string {
+	var b strings.Builder
+	for _, id := range tokens {
+		b.WriteString(t.ids[id])
+	}
+	return strings.ReplaceAll(b.String(), spSpaceMarker, " ")
+}